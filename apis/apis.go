@@ -0,0 +1,31 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package apis contains Kubernetes API groups used by Gatekeeper.
+package apis
+
+import (
+	mutationsv1alpha1 "github.com/open-policy-agent/gatekeeper/apis/mutations/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// AddToSchemes may be used to add all resources defined in the project to a Scheme.
+var AddToSchemes runtime.SchemeBuilder
+
+func init() {
+	AddToSchemes = append(AddToSchemes, mutationsv1alpha1.AddToScheme)
+}
+
+// AddToScheme adds all registered types to scheme.
+func AddToScheme(scheme *runtime.Scheme) error {
+	return AddToSchemes.AddToScheme(scheme)
+}
@@ -0,0 +1,65 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AssignSpec defines the desired state of Assign.
+type AssignSpec struct {
+	ApplyTo []ApplyTo `json:"applyTo,omitempty"`
+	Match   Match     `json:"match,omitempty"`
+
+	// Location describes the dotted path to the field to be mutated, e.g.
+	// "spec.template.spec.priority". Each segment names a map key; list
+	// selectors such as "containers[name:foo]" are not supported and are
+	// rejected when the Assign is compiled.
+	Location string `json:"location,omitempty"`
+
+	// Parameters contains the mutation to perform on the matched object.
+	Parameters Parameters `json:"parameters,omitempty"`
+}
+
+// Parameters contains the assign payload.
+type Parameters struct {
+	Assign AssignField `json:"assign,omitempty"`
+}
+
+// AssignStatus defines the observed state of Assign.
+type AssignStatus struct{}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+
+// Assign is the Schema for the assign API.
+type Assign struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AssignSpec   `json:"spec,omitempty"`
+	Status AssignStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AssignList contains a list of Assign.
+type AssignList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Assign `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Assign{}, &AssignList{})
+}
@@ -0,0 +1,67 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AssignMetadataSpec defines the desired state of AssignMetadata.
+//
+// AssignMetadata is intentionally restricted to metadata.labels and
+// metadata.annotations so that it cannot be used to mutate an object's
+// spec - use Assign for that.
+type AssignMetadataSpec struct {
+	Match Match `json:"match,omitempty"`
+
+	// Location describes the metadata path to be mutated, e.g.
+	// "metadata.labels.foo".
+	Location string `json:"location,omitempty"`
+
+	// Parameters contains the mutation to perform on the matched object.
+	Parameters MetadataParameters `json:"parameters,omitempty"`
+}
+
+// MetadataParameters contains the assign payload for an AssignMetadata
+// mutation.
+type MetadataParameters struct {
+	Assign AssignField `json:"assign,omitempty"`
+}
+
+// AssignMetadataStatus defines the observed state of AssignMetadata.
+type AssignMetadataStatus struct{}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+
+// AssignMetadata is the Schema for the assignmetadata API.
+type AssignMetadata struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AssignMetadataSpec   `json:"spec,omitempty"`
+	Status AssignMetadataStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AssignMetadataList contains a list of AssignMetadata.
+type AssignMetadataList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AssignMetadata `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AssignMetadata{}, &AssignMetadataList{})
+}
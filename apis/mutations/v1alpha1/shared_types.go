@@ -0,0 +1,97 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApplyTo determines what GVKs items the mutation should apply to.
+// An empty list, or an entry of "*", matches any value; other glob
+// patterns are not supported and are compared literally.
+type ApplyTo struct {
+	Groups   []string `json:"groups,omitempty"`
+	Kinds    []string `json:"kinds,omitempty"`
+	Versions []string `json:"versions,omitempty"`
+}
+
+// Match selects which objects a mutator should apply to.
+// An empty Match matches every object.
+type Match struct {
+	// Kinds accepts a list of objects with apiGroups and kinds fields that
+	// list the groups/kinds that this mutator should apply to. If multiple
+	// groups/kinds objects are specified, only one needs to match for the
+	// object to be in scope.
+	Kinds []Kinds `json:"kinds,omitempty"`
+
+	// Scope determines if cluster-scoped and/or namespaced-scoped resources
+	// are selected. Must be "Namespaced", "Cluster", or unset (defaults to
+	// both).
+	Scope string `json:"scope,omitempty"`
+
+	// Namespaces is a list of namespace names the mutator will apply to.
+	// Namespace is the namespace applied to the object.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// ExcludedNamespaces is a list of namespace names that the mutator will
+	// not apply to.
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
+
+	// LabelSelector selects objects by their labels.
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// NamespaceSelector selects objects in namespaces matching this label
+	// selector.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// Kinds accepts a list of objects with apiGroups and kinds fields that list
+// the groups/kinds that a mutator can be applied to.
+type Kinds struct {
+	Kinds     []string `json:"kinds,omitempty"`
+	APIGroups []string `json:"apiGroups,omitempty"`
+}
+
+// AssignField is used to assign a value to a field within an object.
+type AssignField struct {
+	// Value is a constant value that will be assigned to the field.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Value *Anything `json:"value,omitempty"`
+
+	// FromMetadata assigns a value from the specified metadata field.
+	FromMetadata *FromMetadata `json:"fromMetadata,omitempty"`
+}
+
+// FromMetadata instructs the mutator to copy a value from the object's
+// metadata into the assigned field.
+type FromMetadata struct {
+	Field string `json:"field,omitempty"`
+}
+
+// Anything is used to hold arbitrary JSON/YAML values in the CRD spec.
+// +kubebuilder:pruning:PreserveUnknownFields
+type Anything struct {
+	Value interface{} `json:"-"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (in *Anything) MarshalJSON() ([]byte, error) {
+	return json.Marshal(in.Value)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (in *Anything) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &in.Value)
+}
@@ -0,0 +1,195 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mutation wires the Assign and AssignMetadata CRDs into the
+// in-memory mutation.System consulted by the mutation webhook.
+package mutation
+
+import (
+	"context"
+
+	mutationsv1alpha1 "github.com/open-policy-agent/gatekeeper/apis/mutations/v1alpha1"
+	"github.com/open-policy-agent/gatekeeper/pkg/mutation"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var log = logf.Log.WithName("controller").WithValues("metaKind", "mutation")
+
+// AddToManagerFuncs is a list of functions to add controllers to a manager,
+// populated by the reconcilers in this package's init functions.
+var AddToManagerFuncs []func(manager.Manager, *mutation.System, *mutation.Tracker) error
+
+// AddToManager adds every registered mutation controller to mgr, wiring
+// them all up to sync the same System and report ingestion progress to
+// tracker.
+func AddToManager(mgr manager.Manager, system *mutation.System, tracker *mutation.Tracker) error {
+	for _, f := range AddToManagerFuncs {
+		if err := f(mgr, system, tracker); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	AddToManagerFuncs = append(AddToManagerFuncs, addAssignController, addAssignMetadataController)
+}
+
+// PopulateTracker performs a direct, uncached list of every Assign and
+// AssignMetadata object and records each one as expected on tracker, then
+// marks tracker populated. Reconcile calls driven by the informer's cache
+// would eventually do the same thing, but only once the cache has synced
+// and the initial watch events have drained through the workqueue - a
+// window during which the mutation webhook must not treat the Tracker as
+// satisfied. Callers must run this, synchronously, before the webhook
+// server starts accepting requests.
+func PopulateTracker(ctx context.Context, reader client.Reader, tracker *mutation.Tracker) error {
+	assigns := &mutationsv1alpha1.AssignList{}
+	if err := reader.List(ctx, assigns); err != nil {
+		return err
+	}
+	for i := range assigns.Items {
+		a := &assigns.Items[i]
+		tracker.ExpectMutator(mutation.ID{Group: mutationsv1alpha1.GroupVersion.Group, Kind: "Assign", Namespace: a.Namespace, Name: a.Name})
+	}
+
+	assignMetadatas := &mutationsv1alpha1.AssignMetadataList{}
+	if err := reader.List(ctx, assignMetadatas); err != nil {
+		return err
+	}
+	for i := range assignMetadatas.Items {
+		am := &assignMetadatas.Items[i]
+		tracker.ExpectMutator(mutation.ID{Group: mutationsv1alpha1.GroupVersion.Group, Kind: "AssignMetadata", Namespace: am.Namespace, Name: am.Name})
+	}
+
+	tracker.MarkPopulated()
+	return nil
+}
+
+// assignReconciler keeps mutation.System in sync with the cluster's Assign
+// objects.
+type assignReconciler struct {
+	reconcile.Reconciler
+	system  *mutation.System
+	tracker *mutation.Tracker
+	getter  func(ctx context.Context, key types.NamespacedName) (*mutationsv1alpha1.Assign, error)
+}
+
+func addAssignController(mgr manager.Manager, system *mutation.System, tracker *mutation.Tracker) error {
+	r := &assignReconciler{system: system, tracker: tracker, getter: assignGetter(mgr)}
+	c, err := ctrl.NewControllerManagedBy(mgr).For(&mutationsv1alpha1.Assign{}).Build(r)
+	if err != nil {
+		return err
+	}
+	_ = c
+	return nil
+}
+
+func assignGetter(mgr manager.Manager) func(ctx context.Context, key types.NamespacedName) (*mutationsv1alpha1.Assign, error) {
+	return func(ctx context.Context, key types.NamespacedName) (*mutationsv1alpha1.Assign, error) {
+		assign := &mutationsv1alpha1.Assign{}
+		if err := mgr.GetClient().Get(ctx, key, assign); err != nil {
+			return nil, err
+		}
+		return assign, nil
+	}
+}
+
+// Reconcile implements reconcile.Reconciler.
+func (r *assignReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	id := mutation.ID{Group: mutationsv1alpha1.GroupVersion.Group, Kind: "Assign", Namespace: req.Namespace, Name: req.Name}
+
+	assign, err := r.getter(ctx, req.NamespacedName)
+	if apierrors.IsNotFound(err) {
+		r.system.Remove(id)
+		r.tracker.CancelExpect(id)
+		return reconcile.Result{}, nil
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	r.tracker.ExpectMutator(id)
+
+	mutator, err := mutation.MutatorForAssign(assign)
+	if err != nil {
+		log.Error(err, "failed to compile Assign", "name", req.Name, "namespace", req.Namespace)
+		r.system.Remove(id)
+		return reconcile.Result{}, nil
+	}
+
+	r.system.Upsert(mutator)
+	r.tracker.Satisfy(id)
+	return reconcile.Result{}, nil
+}
+
+// assignMetadataReconciler keeps mutation.System in sync with the cluster's
+// AssignMetadata objects.
+type assignMetadataReconciler struct {
+	reconcile.Reconciler
+	system  *mutation.System
+	tracker *mutation.Tracker
+	getter  func(ctx context.Context, key types.NamespacedName) (*mutationsv1alpha1.AssignMetadata, error)
+}
+
+func addAssignMetadataController(mgr manager.Manager, system *mutation.System, tracker *mutation.Tracker) error {
+	r := &assignMetadataReconciler{system: system, tracker: tracker, getter: assignMetadataGetter(mgr)}
+	c, err := ctrl.NewControllerManagedBy(mgr).For(&mutationsv1alpha1.AssignMetadata{}).Build(r)
+	if err != nil {
+		return err
+	}
+	_ = c
+	return nil
+}
+
+func assignMetadataGetter(mgr manager.Manager) func(ctx context.Context, key types.NamespacedName) (*mutationsv1alpha1.AssignMetadata, error) {
+	return func(ctx context.Context, key types.NamespacedName) (*mutationsv1alpha1.AssignMetadata, error) {
+		am := &mutationsv1alpha1.AssignMetadata{}
+		if err := mgr.GetClient().Get(ctx, key, am); err != nil {
+			return nil, err
+		}
+		return am, nil
+	}
+}
+
+// Reconcile implements reconcile.Reconciler.
+func (r *assignMetadataReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	id := mutation.ID{Group: mutationsv1alpha1.GroupVersion.Group, Kind: "AssignMetadata", Namespace: req.Namespace, Name: req.Name}
+
+	am, err := r.getter(ctx, req.NamespacedName)
+	if apierrors.IsNotFound(err) {
+		r.system.Remove(id)
+		r.tracker.CancelExpect(id)
+		return reconcile.Result{}, nil
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	r.tracker.ExpectMutator(id)
+
+	mutator, err := mutation.MutatorForAssignMetadata(am)
+	if err != nil {
+		log.Error(err, "failed to compile AssignMetadata", "name", req.Name, "namespace", req.Namespace)
+		r.system.Remove(id)
+		return reconcile.Result{}, nil
+	}
+
+	r.system.Upsert(mutator)
+	r.tracker.Satisfy(id)
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,99 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutation
+
+import (
+	"fmt"
+	"strings"
+
+	mutationsv1alpha1 "github.com/open-policy-agent/gatekeeper/apis/mutations/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// AssignMutator is a Mutator backed by an Assign CRD. It can set any field
+// reachable via a dotted Location path.
+type AssignMutator struct {
+	assign *mutationsv1alpha1.Assign
+	path   []string
+}
+
+var _ Mutator = &AssignMutator{}
+
+// MutatorForAssign compiles an Assign object into a Mutator.
+func MutatorForAssign(assign *mutationsv1alpha1.Assign) (*AssignMutator, error) {
+	path := strings.Split(assign.Spec.Location, ".")
+	if len(path) == 0 || path[0] == "" {
+		return nil, fmt.Errorf("assign %q has an empty location", assign.GetName())
+	}
+	for _, segment := range path {
+		if strings.ContainsAny(segment, "[]") {
+			return nil, fmt.Errorf("assign %q location %q uses a list selector, which is not supported: Location must be a plain dotted path of map keys", assign.GetName(), assign.Spec.Location)
+		}
+	}
+
+	return &AssignMutator{assign: assign.DeepCopy(), path: path}, nil
+}
+
+// ID implements Mutator.
+func (m *AssignMutator) ID() ID {
+	return ID{
+		Group:     mutationsv1alpha1.GroupVersion.Group,
+		Kind:      "Assign",
+		Namespace: m.assign.GetNamespace(),
+		Name:      m.assign.GetName(),
+	}
+}
+
+// Matches implements Mutator.
+func (m *AssignMutator) Matches(obj *unstructured.Unstructured, ns string, nsLabels map[string]string) (bool, error) {
+	if len(m.assign.Spec.ApplyTo) > 0 && !applyToMatches(m.assign.Spec.ApplyTo, obj) {
+		return false, nil
+	}
+	return Matches(&m.assign.Spec.Match, obj, ns, nsLabels)
+}
+
+// Mutate implements Mutator.
+func (m *AssignMutator) Mutate(obj *unstructured.Unstructured) (bool, error) {
+	value, err := resolveAssignField(&m.assign.Spec.Parameters.Assign, obj)
+	if err != nil {
+		return false, err
+	}
+
+	current, found, err := unstructured.NestedFieldNoCopy(obj.Object, m.path...)
+	if err != nil {
+		return false, err
+	}
+	if found && equalJSON(current, value) {
+		return false, nil
+	}
+
+	if err := unstructured.SetNestedField(obj.Object, value, m.path...); err != nil {
+		return false, fmt.Errorf("setting %s: %w", m.assign.Spec.Location, err)
+	}
+	return true, nil
+}
+
+// String implements Mutator.
+func (m *AssignMutator) String() string {
+	return fmt.Sprintf("Assign/%s at %s", m.assign.GetName(), m.assign.Spec.Location)
+}
+
+func applyToMatches(applyTo []mutationsv1alpha1.ApplyTo, obj *unstructured.Unstructured) bool {
+	gvk := obj.GroupVersionKind()
+	for _, a := range applyTo {
+		if matchesAny(a.Groups, gvk.Group) && matchesAny(a.Kinds, gvk.Kind) && matchesAny(a.Versions, gvk.Version) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutation
+
+import (
+	"testing"
+
+	mutationsv1alpha1 "github.com/open-policy-agent/gatekeeper/apis/mutations/v1alpha1"
+)
+
+func TestMutatorForAssign(t *testing.T) {
+	tcs := []struct {
+		name     string
+		location string
+		wantErr  bool
+	}{
+		{name: "plain dotted path", location: "spec.priority"},
+		{name: "empty location", location: "", wantErr: true},
+		{name: "list selector rejected", location: "spec.containers[name:foo].imagePullPolicy", wantErr: true},
+		{name: "bare bracket rejected", location: "spec.containers]", wantErr: true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assign := &mutationsv1alpha1.Assign{Spec: mutationsv1alpha1.AssignSpec{Location: tc.location}}
+			_, err := MutatorForAssign(assign)
+			if tc.wantErr && err == nil {
+				t.Fatalf("MutatorForAssign(%q) returned no error, want one", tc.location)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("MutatorForAssign(%q) returned unexpected error: %v", tc.location, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutation
+
+import (
+	"fmt"
+	"strings"
+
+	mutationsv1alpha1 "github.com/open-policy-agent/gatekeeper/apis/mutations/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// AssignMetadataMutator is a Mutator backed by an AssignMetadata CRD. Its
+// Location is restricted to metadata.labels.* and metadata.annotations.*.
+type AssignMetadataMutator struct {
+	assignMetadata *mutationsv1alpha1.AssignMetadata
+	path           []string
+}
+
+var _ Mutator = &AssignMetadataMutator{}
+
+// MutatorForAssignMetadata compiles an AssignMetadata object into a Mutator.
+func MutatorForAssignMetadata(am *mutationsv1alpha1.AssignMetadata) (*AssignMetadataMutator, error) {
+	// Split only on the first two dots: metadata.labels.<key> or
+	// metadata.annotations.<key>. <key> is taken verbatim as the third
+	// segment, not split any further, since label/annotation keys are
+	// almost always prefixed (e.g. "app.kubernetes.io/name") and contain
+	// dots of their own.
+	path := strings.SplitN(am.Spec.Location, ".", 3)
+	if len(path) != 3 || path[0] != "metadata" || (path[1] != "labels" && path[1] != "annotations") || path[2] == "" {
+		return nil, fmt.Errorf("assignmetadata %q location %q must be of the form metadata.labels.<key> or metadata.annotations.<key>", am.GetName(), am.Spec.Location)
+	}
+
+	return &AssignMetadataMutator{assignMetadata: am.DeepCopy(), path: path}, nil
+}
+
+// ID implements Mutator.
+func (m *AssignMetadataMutator) ID() ID {
+	return ID{
+		Group:     mutationsv1alpha1.GroupVersion.Group,
+		Kind:      "AssignMetadata",
+		Namespace: m.assignMetadata.GetNamespace(),
+		Name:      m.assignMetadata.GetName(),
+	}
+}
+
+// Matches implements Mutator.
+func (m *AssignMetadataMutator) Matches(obj *unstructured.Unstructured, ns string, nsLabels map[string]string) (bool, error) {
+	return Matches(&m.assignMetadata.Spec.Match, obj, ns, nsLabels)
+}
+
+// Mutate implements Mutator.
+func (m *AssignMetadataMutator) Mutate(obj *unstructured.Unstructured) (bool, error) {
+	value, err := resolveAssignField(&m.assignMetadata.Spec.Parameters.Assign, obj)
+	if err != nil {
+		return false, err
+	}
+	strValue, ok := value.(string)
+	if !ok {
+		return false, fmt.Errorf("assignmetadata %q must assign a string value", m.assignMetadata.GetName())
+	}
+
+	current, found, err := unstructured.NestedString(obj.Object, m.path...)
+	if err != nil {
+		return false, err
+	}
+	if found && current == strValue {
+		return false, nil
+	}
+
+	if err := unstructured.SetNestedField(obj.Object, strValue, m.path...); err != nil {
+		return false, fmt.Errorf("setting %s: %w", m.assignMetadata.Spec.Location, err)
+	}
+	return true, nil
+}
+
+// String implements Mutator.
+func (m *AssignMetadataMutator) String() string {
+	return fmt.Sprintf("AssignMetadata/%s at %s", m.assignMetadata.GetName(), m.assignMetadata.Spec.Location)
+}
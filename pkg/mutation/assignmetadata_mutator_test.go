@@ -0,0 +1,59 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutation
+
+import (
+	"testing"
+
+	mutationsv1alpha1 "github.com/open-policy-agent/gatekeeper/apis/mutations/v1alpha1"
+)
+
+func TestMutatorForAssignMetadata(t *testing.T) {
+	tcs := []struct {
+		name     string
+		location string
+		wantErr  bool
+		wantPath []string
+	}{
+		{name: "unprefixed label", location: "metadata.labels.foo", wantPath: []string{"metadata", "labels", "foo"}},
+		{name: "prefixed label", location: "metadata.labels.app.kubernetes.io/name", wantPath: []string{"metadata", "labels", "app.kubernetes.io/name"}},
+		{name: "prefixed annotation", location: "metadata.annotations.team.corp.io/owner", wantPath: []string{"metadata", "annotations", "team.corp.io/owner"}},
+		{name: "wrong prefix", location: "spec.labels.foo", wantErr: true},
+		{name: "missing key", location: "metadata.labels.", wantErr: true},
+		{name: "too short", location: "metadata.labels", wantErr: true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			am := &mutationsv1alpha1.AssignMetadata{Spec: mutationsv1alpha1.AssignMetadataSpec{Location: tc.location}}
+			m, err := MutatorForAssignMetadata(am)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("MutatorForAssignMetadata(%q) returned no error, want one", tc.location)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MutatorForAssignMetadata(%q) returned unexpected error: %v", tc.location, err)
+			}
+			if len(m.path) != len(tc.wantPath) {
+				t.Fatalf("path = %v, want %v", m.path, tc.wantPath)
+			}
+			for i := range tc.wantPath {
+				if m.path[i] != tc.wantPath[i] {
+					t.Fatalf("path = %v, want %v", m.path, tc.wantPath)
+				}
+			}
+		})
+	}
+}
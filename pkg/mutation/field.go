@@ -0,0 +1,49 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutation
+
+import (
+	"fmt"
+	"reflect"
+
+	mutationsv1alpha1 "github.com/open-policy-agent/gatekeeper/apis/mutations/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// resolveAssignField computes the concrete value an AssignField should set
+// on obj, either a constant or a value copied from the object's own
+// metadata.
+func resolveAssignField(f *mutationsv1alpha1.AssignField, obj *unstructured.Unstructured) (interface{}, error) {
+	if f.FromMetadata != nil {
+		val, found, err := unstructured.NestedFieldNoCopy(obj.Object, "metadata", f.FromMetadata.Field)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("fromMetadata field %q not present on object", f.FromMetadata.Field)
+		}
+		return val, nil
+	}
+
+	if f.Value != nil {
+		return f.Value.Value, nil
+	}
+
+	return nil, fmt.Errorf("assign field has neither value nor fromMetadata set")
+}
+
+// equalJSON performs a deep-equal comparison suitable for the loosely typed
+// values produced by unstructured field access.
+func equalJSON(a, b interface{}) bool {
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,119 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutation
+
+import (
+	"fmt"
+
+	mutationsv1alpha1 "github.com/open-policy-agent/gatekeeper/apis/mutations/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// scopeNamespaced and scopeCluster are the only values Match.Scope accepts,
+// matching apiextensionsv1.ResourceScope's "Namespaced"/"Cluster" spelling.
+const (
+	scopeNamespaced = "Namespaced"
+	scopeCluster    = "Cluster"
+)
+
+// Matches reports whether obj, sitting in namespace ns whose Namespace
+// object carries nsLabels, is selected by m. An empty Match selects every
+// object. ns == "" is treated as a cluster-scoped object, matching how the
+// mutation webhook calls Matches; nsLabels should be nil in that case, and
+// a Match with NamespaceSelector set will never select a cluster-scoped
+// object.
+func Matches(m *mutationsv1alpha1.Match, obj *unstructured.Unstructured, ns string, nsLabels map[string]string) (bool, error) {
+	switch m.Scope {
+	case "":
+		// unset selects both scopes.
+	case scopeNamespaced:
+		if ns == "" {
+			return false, nil
+		}
+	case scopeCluster:
+		if ns != "" {
+			return false, nil
+		}
+	default:
+		return false, fmt.Errorf("match scope %q must be %q, %q, or unset", m.Scope, scopeNamespaced, scopeCluster)
+	}
+
+	if len(m.Kinds) > 0 && !matchesKinds(m.Kinds, obj) {
+		return false, nil
+	}
+
+	if len(m.Namespaces) > 0 && !contains(m.Namespaces, ns) {
+		return false, nil
+	}
+
+	if len(m.ExcludedNamespaces) > 0 && contains(m.ExcludedNamespaces, ns) {
+		return false, nil
+	}
+
+	if m.LabelSelector != nil {
+		sel, err := metav1.LabelSelectorAsSelector(m.LabelSelector)
+		if err != nil {
+			return false, err
+		}
+		if !sel.Matches(labels.Set(obj.GetLabels())) {
+			return false, nil
+		}
+	}
+
+	if m.NamespaceSelector != nil {
+		if ns == "" {
+			return false, nil
+		}
+		sel, err := metav1.LabelSelectorAsSelector(m.NamespaceSelector)
+		if err != nil {
+			return false, err
+		}
+		if !sel.Matches(labels.Set(nsLabels)) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func matchesKinds(kinds []mutationsv1alpha1.Kinds, obj *unstructured.Unstructured) bool {
+	gvk := obj.GroupVersionKind()
+	for _, k := range kinds {
+		if !matchesAny(k.APIGroups, gvk.Group) {
+			continue
+		}
+		if matchesAny(k.Kinds, gvk.Kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAny returns true if candidates is empty (wildcard) or contains val.
+func matchesAny(candidates []string, val string) bool {
+	if len(candidates) == 0 {
+		return true
+	}
+	return contains(candidates, val)
+}
+
+func contains(list []string, val string) bool {
+	for _, v := range list {
+		if v == val || v == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutation
+
+import (
+	"testing"
+
+	mutationsv1alpha1 "github.com/open-policy-agent/gatekeeper/apis/mutations/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func podObj(labels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+	}}
+	obj.SetLabels(labels)
+	return obj
+}
+
+func TestMatches_Scope(t *testing.T) {
+	tcs := []struct {
+		name    string
+		scope   string
+		ns      string
+		want    bool
+		wantErr bool
+	}{
+		{name: "unset matches namespaced", scope: "", ns: "default", want: true},
+		{name: "unset matches cluster", scope: "", ns: "", want: true},
+		{name: "namespaced rejects cluster-scoped", scope: "Namespaced", ns: "", want: false},
+		{name: "namespaced accepts namespaced", scope: "Namespaced", ns: "default", want: true},
+		{name: "cluster rejects namespaced", scope: "Cluster", ns: "default", want: false},
+		{name: "cluster accepts cluster-scoped", scope: "Cluster", ns: "", want: true},
+		{name: "invalid scope errors", scope: "bogus", ns: "default", wantErr: true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &mutationsv1alpha1.Match{Scope: tc.scope}
+			got, err := Matches(m, podObj(nil), tc.ns, nil)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("Matches() returned no error, want one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Matches() returned unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("Matches() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMatches_LabelSelector(t *testing.T) {
+	m := &mutationsv1alpha1.Match{
+		LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+	}
+
+	got, err := Matches(m, podObj(map[string]string{"team": "a"}), "default", nil)
+	if err != nil {
+		t.Fatalf("Matches() returned unexpected error: %v", err)
+	}
+	if !got {
+		t.Fatalf("Matches() = false, want true for a label-matching object")
+	}
+
+	got, err = Matches(m, podObj(map[string]string{"team": "b"}), "default", nil)
+	if err != nil {
+		t.Fatalf("Matches() returned unexpected error: %v", err)
+	}
+	if got {
+		t.Fatalf("Matches() = true, want false for a non-matching object")
+	}
+}
+
+func TestMatches_Namespaces(t *testing.T) {
+	m := &mutationsv1alpha1.Match{
+		Namespaces:         []string{"allowed"},
+		ExcludedNamespaces: []string{"denied"},
+	}
+
+	if got, _ := Matches(m, podObj(nil), "allowed", nil); !got {
+		t.Fatalf("Matches() = false, want true for a namespace on the allowlist")
+	}
+	if got, _ := Matches(m, podObj(nil), "other", nil); got {
+		t.Fatalf("Matches() = true, want false for a namespace not on the allowlist")
+	}
+
+	m = &mutationsv1alpha1.Match{ExcludedNamespaces: []string{"denied"}}
+	if got, _ := Matches(m, podObj(nil), "denied", nil); got {
+		t.Fatalf("Matches() = true, want false for an excluded namespace")
+	}
+}
+
+func TestMatches_NamespaceSelector(t *testing.T) {
+	m := &mutationsv1alpha1.Match{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+	}
+
+	got, err := Matches(m, podObj(nil), "default", map[string]string{"env": "prod"})
+	if err != nil {
+		t.Fatalf("Matches() returned unexpected error: %v", err)
+	}
+	if !got {
+		t.Fatalf("Matches() = false, want true when the namespace's labels match NamespaceSelector")
+	}
+
+	got, err = Matches(m, podObj(nil), "default", map[string]string{"env": "staging"})
+	if err != nil {
+		t.Fatalf("Matches() returned unexpected error: %v", err)
+	}
+	if got {
+		t.Fatalf("Matches() = true, want false when the namespace's labels don't match NamespaceSelector")
+	}
+
+	// A cluster-scoped object has no owning Namespace to select on, so
+	// nsLabels is nil and a NamespaceSelector never matches.
+	got, err = Matches(m, podObj(nil), "", nil)
+	if err != nil {
+		t.Fatalf("Matches() returned unexpected error: %v", err)
+	}
+	if got {
+		t.Fatalf("Matches() = true, want false for a cluster-scoped object when NamespaceSelector is set")
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mutation holds the in-memory mutation System that the mutation
+// webhook consults on every admission request, plus the concrete Mutator
+// implementations backing the Assign/AssignMetadata CRDs.
+package mutation
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ID uniquely identifies a mutator within the System, independent of its
+// current ApplyTo/Match configuration.
+type ID struct {
+	Group     string
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// Mutator is a compiled mutation rule the System applies to matching
+// objects. Implementations wrap an Assign or AssignMetadata CRD.
+type Mutator interface {
+	// ID returns the identity of the mutator, used to Upsert/Remove it from
+	// the System as the backing CRD is created/updated/deleted.
+	ID() ID
+
+	// Matches returns true if this mutator applies to obj, which sits in
+	// namespace ns (ns == "" for cluster-scoped objects) carrying nsLabels
+	// (the labels on the Namespace object itself, not obj's own labels;
+	// nil for cluster-scoped objects). nsLabels is used to evaluate the
+	// Match's NamespaceSelector, if any.
+	Matches(obj *unstructured.Unstructured, ns string, nsLabels map[string]string) (bool, error)
+
+	// Mutate applies the mutation to obj in place. The returned bool
+	// indicates whether obj was changed.
+	Mutate(obj *unstructured.Unstructured) (bool, error)
+
+	// String returns a human-readable description of the mutator, used for
+	// logging which mutator fired or is preventing convergence.
+	String() string
+}
@@ -0,0 +1,127 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutation
+
+import (
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// System holds the compiled mutators currently known to Gatekeeper and
+// applies them to incoming objects. Controllers watching the Assign and
+// AssignMetadata CRDs keep it in sync via Upsert/Remove; the mutation
+// webhook is the only reader.
+type System struct {
+	mux sync.RWMutex
+
+	// mutators is keyed by the mutator's own identity so a CRD update can
+	// replace its compiled form in place. Only the compile order (by ID)
+	// is required to be deterministic; the group/version/kind the mutator
+	// applies to is a property of each Mutator, not of this map.
+	mutators map[ID]Mutator
+}
+
+// NewSystem creates an empty mutation System.
+func NewSystem() *System {
+	return &System{mutators: make(map[ID]Mutator)}
+}
+
+// Upsert adds m to the System, replacing any previous mutator with the same
+// ID.
+func (s *System) Upsert(m Mutator) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.mutators[m.ID()] = m
+}
+
+// Remove removes the mutator with the given ID, if present.
+func (s *System) Remove(id ID) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	delete(s.mutators, id)
+}
+
+// Get returns the mutator with the given ID, if any.
+func (s *System) Get(id ID) (Mutator, bool) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	m, ok := s.mutators[id]
+	return m, ok
+}
+
+// Len returns the number of mutators currently compiled into the System.
+func (s *System) Len() int {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return len(s.mutators)
+}
+
+// orderedMutators returns every compiled mutator sorted by ID so that
+// repeated calls with the same System state apply mutators in the same
+// order, regardless of Go's randomized map iteration.
+func (s *System) orderedMutators() []Mutator {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	out := make([]Mutator, 0, len(s.mutators))
+	for _, m := range s.mutators {
+		out = append(out, m)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return lessID(out[i].ID(), out[j].ID())
+	})
+	return out
+}
+
+func lessID(a, b ID) bool {
+	if a.Group != b.Group {
+		return a.Group < b.Group
+	}
+	if a.Kind != b.Kind {
+		return a.Kind < b.Kind
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// MutatePass applies every matching mutator, in deterministic order, to obj
+// exactly once and reports which mutators changed it. Callers that need a
+// fixed point across mutators that depend on each other's output should
+// call MutatePass repeatedly until it reports no mutators fired. nsLabels
+// are the labels on obj's own Namespace object (nil for cluster-scoped
+// objects), used to evaluate any mutator's NamespaceSelector; the caller is
+// responsible for resolving it since System has no client of its own.
+func (s *System) MutatePass(obj *unstructured.Unstructured, ns string, nsLabels map[string]string) ([]Mutator, error) {
+	var fired []Mutator
+	for _, m := range s.orderedMutators() {
+		ok, err := m.Matches(obj, ns, nsLabels)
+		if err != nil {
+			return fired, err
+		}
+		if !ok {
+			continue
+		}
+		changed, err := m.Mutate(obj)
+		if err != nil {
+			return fired, err
+		}
+		if changed {
+			fired = append(fired, m)
+		}
+	}
+	return fired, nil
+}
@@ -0,0 +1,143 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutation
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// fakeMutator sets field to value the first time it sees an object missing
+// it, letting tests exercise MutatePass's single-pass-per-call contract and
+// a caller's repeated-calls-to-convergence pattern. If sourceField is set,
+// it only fires once obj already has that field populated, letting a test
+// build a dependency chain between two fakeMutators.
+type fakeMutator struct {
+	id          ID
+	field       string
+	value       string
+	sourceField string
+}
+
+var _ Mutator = &fakeMutator{}
+
+func (m *fakeMutator) ID() ID { return m.id }
+
+func (m *fakeMutator) Matches(obj *unstructured.Unstructured, ns string, nsLabels map[string]string) (bool, error) {
+	if m.sourceField == "" {
+		return true, nil
+	}
+	_, found, _ := unstructured.NestedString(obj.Object, m.sourceField)
+	return found, nil
+}
+
+func (m *fakeMutator) Mutate(obj *unstructured.Unstructured) (bool, error) {
+	value := m.value
+	if m.sourceField != "" {
+		value, _, _ = unstructured.NestedString(obj.Object, m.sourceField)
+	}
+
+	current, found, _ := unstructured.NestedString(obj.Object, m.field)
+	if found && current == value {
+		return false, nil
+	}
+	_ = unstructured.SetNestedField(obj.Object, value, m.field)
+	return true, nil
+}
+
+func (m *fakeMutator) String() string { return m.id.Name }
+
+func TestSystem_MutatePassAppliesAllMatchingMutatorsOnce(t *testing.T) {
+	s := NewSystem()
+	s.Upsert(&fakeMutator{id: ID{Kind: "Assign", Name: "a"}, field: "a", value: "1"})
+	s.Upsert(&fakeMutator{id: ID{Kind: "Assign", Name: "b"}, field: "b", value: "1"})
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	fired, err := s.MutatePass(obj, "default", nil)
+	if err != nil {
+		t.Fatalf("MutatePass returned unexpected error: %v", err)
+	}
+	if len(fired) != 2 {
+		t.Fatalf("MutatePass fired %d mutators, want 2", len(fired))
+	}
+
+	// A second pass over an already-mutated object should be a no-op: both
+	// mutators report no change since their fields already hold the
+	// assigned value.
+	fired, err = s.MutatePass(obj, "default", nil)
+	if err != nil {
+		t.Fatalf("MutatePass returned unexpected error: %v", err)
+	}
+	if len(fired) != 0 {
+		t.Fatalf("MutatePass fired %d mutators on a converged object, want 0", len(fired))
+	}
+}
+
+func TestSystem_MutatePassConvergesChainedMutators(t *testing.T) {
+	s := NewSystem()
+	// "a-consumer" copies the value of field "a" into field "b", but only
+	// matches once "a" is already present on the object; "z-producer" sets
+	// "a" unconditionally. orderedMutators applies mutators alphabetically
+	// by ID within a single MutatePass call, so "a-consumer" always runs
+	// before "z-producer" in the same pass and cannot see "a" until a
+	// second MutatePass call — a single pass only advances one hop of the
+	// chain, so a caller must call MutatePass repeatedly to reach a fixed
+	// point, matching mutationHandler.mutateRequest's loop.
+	s.Upsert(&fakeMutator{id: ID{Kind: "Assign", Name: "a-consumer"}, field: "b", sourceField: "a"})
+	s.Upsert(&fakeMutator{id: ID{Kind: "Assign", Name: "z-producer"}, field: "a", value: "1"})
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+
+	iterations := 0
+	for {
+		iterations++
+		fired, err := s.MutatePass(obj, "default", nil)
+		if err != nil {
+			t.Fatalf("MutatePass returned unexpected error: %v", err)
+		}
+		if len(fired) == 0 {
+			break
+		}
+		if iterations > 10 {
+			t.Fatalf("MutatePass did not converge within 10 iterations")
+		}
+	}
+	if iterations < 3 {
+		t.Fatalf("MutatePass converged in %d iterations, want at least 3 (one to produce \"a\", one to consume it into \"b\", one to observe convergence)", iterations)
+	}
+
+	a, _, _ := unstructured.NestedString(obj.Object, "a")
+	b, _, _ := unstructured.NestedString(obj.Object, "b")
+	if a != "1" || b != "1" {
+		t.Fatalf("object = %+v, want both fields set to 1 after convergence", obj.Object)
+	}
+}
+
+func TestSystem_RemoveAndGet(t *testing.T) {
+	s := NewSystem()
+	m := &fakeMutator{id: ID{Kind: "Assign", Name: "a"}, field: "a", value: "1"}
+	s.Upsert(m)
+
+	if got, ok := s.Get(m.ID()); !ok || got != m {
+		t.Fatalf("Get() = %v, %v; want the mutator just upserted", got, ok)
+	}
+
+	s.Remove(m.ID())
+	if _, ok := s.Get(m.ID()); ok {
+		t.Fatalf("Get() found a mutator after Remove")
+	}
+	if s.Len() != 0 {
+		t.Fatalf("Len() = %d, want 0 after Remove", s.Len())
+	}
+}
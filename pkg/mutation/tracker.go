@@ -0,0 +1,123 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutation
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var errNotSatisfied = errors.New("mutation system has not yet compiled every Assign/AssignMetadata object observed by the informer")
+
+// Tracker records which Assign/AssignMetadata objects the informer has
+// observed versus how many of them have been successfully compiled into
+// the System, mirroring the role readiness.Tracker plays for the
+// validating path. The mutation webhook consults Satisfied to decide
+// whether it is safe to let objects through before the initial set of
+// mutators has finished loading.
+//
+// Satisfied is false until MarkPopulated has been called, even if expected
+// is still empty: otherwise a Tracker would trivially report Satisfied
+// during the window between manager start and the informer's first
+// reconcile of pre-existing objects, the exact "partial enforcement"
+// failure mode readiness exists to close. Callers must perform a direct
+// (non-cached) list of every Assign/AssignMetadata object, call
+// ExpectMutator for each, and then call MarkPopulated before the webhook
+// server starts serving requests.
+type Tracker struct {
+	mux       sync.RWMutex
+	expected  map[ID]bool
+	satisfied map[ID]bool
+	populated bool
+
+	createdAt time.Time
+}
+
+// NewTracker creates an empty, unpopulated Tracker. Satisfied reports false
+// until MarkPopulated is called.
+func NewTracker() *Tracker {
+	return &Tracker{expected: map[ID]bool{}, satisfied: map[ID]bool{}, createdAt: time.Now()}
+}
+
+// MarkPopulated records that the initial, direct list of every
+// Assign/AssignMetadata object has completed and every ID found has been
+// passed to ExpectMutator. Until this is called, Satisfied always reports
+// false.
+func (t *Tracker) MarkPopulated() {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	t.populated = true
+}
+
+// ExpectMutator records that id was observed by the informer and must be
+// compiled into the System before the Tracker reports Satisfied.
+func (t *Tracker) ExpectMutator(id ID) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	t.expected[id] = true
+}
+
+// CancelExpect removes id from the expected set, e.g. because the
+// informer reported it deleted before it was ever compiled.
+func (t *Tracker) CancelExpect(id ID) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	delete(t.expected, id)
+	delete(t.satisfied, id)
+}
+
+// Satisfy records that id has been successfully compiled into the System.
+func (t *Tracker) Satisfy(id ID) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	t.satisfied[id] = true
+}
+
+// Satisfied reports whether the initial population pass has run and every
+// mutator it expected has since been compiled.
+func (t *Tracker) Satisfied() bool {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+	if !t.populated {
+		return false
+	}
+	for id := range t.expected {
+		if !t.satisfied[id] {
+			return false
+		}
+	}
+	return true
+}
+
+// Since returns how long it has been since the Tracker was created. The
+// mutation webhook uses this, not a per-request retry count, to bound how
+// long it blocks requests on startup: a shared counter incremented once per
+// admission request is exhausted by a single burst of concurrent requests
+// (e.g. several pods created at once), which would silently and
+// permanently disable the readiness gate. Wall-clock elapsed since startup
+// isn't affected by how many requests arrive concurrently.
+func (t *Tracker) Since() time.Duration {
+	return time.Since(t.createdAt)
+}
+
+// CheckReady implements the controller-runtime healthz.Checker signature
+// so the Tracker can be registered against the manager's /readyz
+// endpoint.
+func (t *Tracker) CheckReady(_ *http.Request) error {
+	if t.Satisfied() {
+		return nil
+	}
+	return errNotSatisfied
+}
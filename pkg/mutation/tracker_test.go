@@ -0,0 +1,79 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutation
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestTracker_SatisfiedRequiresPopulation(t *testing.T) {
+	tr := NewTracker()
+
+	if tr.Satisfied() {
+		t.Fatalf("Satisfied() = true before MarkPopulated, want false even with an empty expected set")
+	}
+
+	tr.MarkPopulated()
+	if !tr.Satisfied() {
+		t.Fatalf("Satisfied() = false after MarkPopulated with no expected mutators, want true")
+	}
+}
+
+func TestTracker_SatisfiedWaitsForExpectedMutators(t *testing.T) {
+	tr := NewTracker()
+	id := ID{Group: "mutations.gatekeeper.sh", Kind: "Assign", Name: "foo"}
+
+	tr.ExpectMutator(id)
+	tr.MarkPopulated()
+	if tr.Satisfied() {
+		t.Fatalf("Satisfied() = true before the expected mutator was compiled, want false")
+	}
+
+	tr.Satisfy(id)
+	if !tr.Satisfied() {
+		t.Fatalf("Satisfied() = false after the expected mutator was compiled, want true")
+	}
+}
+
+func TestTracker_CancelExpect(t *testing.T) {
+	tr := NewTracker()
+	id := ID{Group: "mutations.gatekeeper.sh", Kind: "Assign", Name: "foo"}
+
+	tr.ExpectMutator(id)
+	tr.MarkPopulated()
+	tr.CancelExpect(id)
+
+	if !tr.Satisfied() {
+		t.Fatalf("Satisfied() = false after CancelExpect removed the only expected mutator, want true")
+	}
+}
+
+func TestTracker_SinceIsIndependentOfConcurrentCallers(t *testing.T) {
+	tr := NewTracker()
+
+	// Since must not be a consumable counter: many concurrent readers
+	// observing it must not change what a later reader sees.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = tr.Since()
+		}()
+	}
+	wg.Wait()
+	if tr.Since() < 0 {
+		t.Fatalf("Since() = %v, want a non-negative duration", tr.Since())
+	}
+}
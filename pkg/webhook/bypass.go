@@ -0,0 +1,123 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+// bypassResourceGroup is the API group of the synthetic "bypass" resource
+// --bypass-via-sar checks access to. That resource names no real API
+// object; it exists purely as the subject of a
+// SubjectAccessReview so cluster-admins can grant break-glass access with
+// an ordinary RBAC rule.
+const bypassResourceGroup = "constraints.gatekeeper.sh"
+
+var (
+	bypassGroups  = &stringSetFlag{values: map[string]bool{}}
+	bypassUsers   = &stringSetFlag{values: map[string]bool{}}
+	bypassViaSAR  = flag.Bool("bypass-via-sar", false, "(alpha) allow a SubjectAccessReview to bypass Gatekeeper webhooks for privileged callers")
+	bypassSARVerb = flag.String("bypass-via-sar-verb", "bypass", "verb checked by --bypass-via-sar's SubjectAccessReview against the synthetic constraints.gatekeeper.sh/bypass resource")
+
+	sarCacheTTL = 10 * time.Second
+	sarCacheMux sync.Mutex
+	sarCache    = map[string]sarCacheEntry{}
+)
+
+type sarCacheEntry struct {
+	allowed bool
+	expiry  time.Time
+}
+
+func init() {
+	flag.Var(bypassGroups, "bypass-group", "group allowed to bypass Gatekeeper webhooks, can be specified multiple times")
+	flag.Var(bypassUsers, "bypass-user", "user allowed to bypass Gatekeeper webhooks, can be specified multiple times")
+}
+
+// isBypassedByGroupOrUser reports whether user was named by a --bypass-user
+// flag, or belongs to a group named by a --bypass-group flag.
+func isBypassedByGroupOrUser(user authenticationv1.UserInfo) bool {
+	if bypassUsers.values[user.Username] {
+		return true
+	}
+	for _, g := range user.Groups {
+		if bypassGroups.values[g] {
+			return true
+		}
+	}
+	return false
+}
+
+// isBypassedBySAR issues a SubjectAccessReview asking whether user holds
+// --bypass-via-sar-verb on the synthetic constraints.gatekeeper.sh/bypass
+// resource, giving cluster-admins break-glass semantics without editing
+// the webhook's failurePolicy or namespace selector. Results are cached by
+// (user, sorted groups) for sarCacheTTL, since this runs on every
+// admission request and the policy backing the SAR rarely changes.
+func isBypassedBySAR(ctx context.Context, c authorizationv1client.SubjectAccessReviewInterface, user authenticationv1.UserInfo) (bool, error) {
+	if !*bypassViaSAR || c == nil {
+		return false, nil
+	}
+
+	key := sarCacheKey(user)
+
+	sarCacheMux.Lock()
+	if entry, ok := sarCache[key]; ok && time.Now().Before(entry.expiry) {
+		sarCacheMux.Unlock()
+		return entry.allowed, nil
+	}
+	sarCacheMux.Unlock()
+
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			Groups: user.Groups,
+			UID:    user.UID,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Group:    bypassResourceGroup,
+				Resource: "bypass",
+				Verb:     *bypassSARVerb,
+			},
+		},
+	}
+
+	result, err := c.Create(ctx, sar, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	sarCacheMux.Lock()
+	sarCache[key] = sarCacheEntry{allowed: result.Status.Allowed, expiry: time.Now().Add(sarCacheTTL)}
+	sarCacheMux.Unlock()
+
+	return result.Status.Allowed, nil
+}
+
+func sarCacheKey(user authenticationv1.UserInfo) string {
+	groups := append([]string{}, user.Groups...)
+	sort.Strings(groups)
+	sum := sha256.Sum256([]byte(user.Username + "|" + strings.Join(groups, ",")))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,82 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+func TestIsBypassedByGroupOrUser(t *testing.T) {
+	bypassUsers = &stringSetFlag{values: map[string]bool{"alice": true}}
+	bypassGroups = &stringSetFlag{values: map[string]bool{"break-glass": true}}
+	t.Cleanup(func() {
+		bypassUsers = &stringSetFlag{values: map[string]bool{}}
+		bypassGroups = &stringSetFlag{values: map[string]bool{}}
+	})
+
+	tcs := []struct {
+		name string
+		user authenticationv1.UserInfo
+		want bool
+	}{
+		{name: "named user", user: authenticationv1.UserInfo{Username: "alice"}, want: true},
+		{name: "member of named group", user: authenticationv1.UserInfo{Username: "bob", Groups: []string{"break-glass"}}, want: true},
+		{name: "neither", user: authenticationv1.UserInfo{Username: "bob", Groups: []string{"other"}}, want: false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isBypassedByGroupOrUser(tc.user); got != tc.want {
+				t.Fatalf("isBypassedByGroupOrUser(%+v) = %v, want %v", tc.user, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSarCacheKey(t *testing.T) {
+	a := authenticationv1.UserInfo{Username: "alice", Groups: []string{"b", "a"}}
+	b := authenticationv1.UserInfo{Username: "alice", Groups: []string{"a", "b"}}
+	if sarCacheKey(a) != sarCacheKey(b) {
+		t.Fatalf("sarCacheKey should be independent of group order")
+	}
+
+	c := authenticationv1.UserInfo{Username: "bob", Groups: []string{"a", "b"}}
+	if sarCacheKey(a) == sarCacheKey(c) {
+		t.Fatalf("sarCacheKey should differ for different usernames")
+	}
+}
+
+func TestSarCacheEntryExpiry(t *testing.T) {
+	key := sarCacheKey(authenticationv1.UserInfo{Username: "alice"})
+
+	sarCacheMux.Lock()
+	sarCache[key] = sarCacheEntry{allowed: true, expiry: time.Now().Add(-time.Second)}
+	sarCacheMux.Unlock()
+	t.Cleanup(func() {
+		sarCacheMux.Lock()
+		delete(sarCache, key)
+		sarCacheMux.Unlock()
+	})
+
+	sarCacheMux.Lock()
+	entry, ok := sarCache[key]
+	expired := ok && !time.Now().Before(entry.expiry)
+	sarCacheMux.Unlock()
+
+	if !expired {
+		t.Fatalf("expected the cache entry to be expired")
+	}
+}
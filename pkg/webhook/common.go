@@ -1,13 +1,18 @@
 package webhook
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"strings"
 
 	"github.com/open-policy-agent/gatekeeper/pkg/util"
 	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -15,6 +20,12 @@ var log = logf.Log.WithName("webhook")
 
 const (
 	serviceAccountName = "gatekeeper-admin"
+
+	// exemptNamespaceLabel must be set to "true" on every namespace
+	// configured via --exempt-namespace; ValidateExemptNamespaces refuses
+	// to start otherwise. A namespaceSelector on the webhook configurations
+	// is not taken into account.
+	exemptNamespaceLabel = "admission.gatekeeper.sh/ignore"
 )
 
 var (
@@ -26,8 +37,69 @@ var (
 	emitAdmissionEvents                = flag.Bool("emit-admission-events", false, "(alpha) emit Kubernetes events in gatekeeper namespace for each admission violation")
 	serviceaccount                     = fmt.Sprintf("system:serviceaccount:%s:%s", util.GetNamespace(), serviceAccountName)
 	// webhookName is deprecated, set this on the manifest YAML if needed"
+
+	exemptNamespaces = &stringSetFlag{values: map[string]bool{}}
 )
 
+func init() {
+	flag.Var(exemptNamespaces, "exempt-namespace", "namespace to exempt from ALL Gatekeeper admission webhooks, can be specified multiple times")
+}
+
 func isGkServiceAccount(user authenticationv1.UserInfo) bool {
 	return user.Username == serviceaccount
 }
+
+// isNamespaceExempt reports whether ns was named in one or more
+// --exempt-namespace flags.
+func isNamespaceExempt(ns string) bool {
+	return exemptNamespaces.values[ns]
+}
+
+// stringSetFlag implements flag.Value to collect a repeatable
+// --flag-name=value1 --flag-name=value2 CLI flag into a set.
+type stringSetFlag struct {
+	values map[string]bool
+}
+
+func (f *stringSetFlag) String() string {
+	names := make([]string, 0, len(f.values))
+	for v := range f.values {
+		names = append(names, v)
+	}
+	return strings.Join(names, ",")
+}
+
+func (f *stringSetFlag) Set(value string) error {
+	f.values[value] = true
+	return nil
+}
+
+// ValidateExemptNamespaces verifies that every namespace named by
+// --exempt-namespace carries the exemptNamespaceLabel with the value
+// "true", and returns an error naming every namespace that does not. Only
+// the Namespace objects are read: a namespaceSelector on the webhook
+// configurations is not consulted, so a namespace excluded only that way
+// still fails this check.
+//
+// Called from AddMutatingWebhook before the webhook server is registered,
+// so the manager fails to start rather than serving traffic against a
+// misconfigured exemption. c is a client.Reader (not client.Client) so this
+// can run against the manager's direct API reader, before the cache has
+// synced.
+func ValidateExemptNamespaces(ctx context.Context, c client.Reader) error {
+	var misconfigured []string
+	for ns := range exemptNamespaces.values {
+		namespace := &corev1.Namespace{}
+		if err := c.Get(ctx, types.NamespacedName{Name: ns}, namespace); err != nil {
+			return fmt.Errorf("looking up exempt namespace %q: %w", ns, err)
+		}
+		if namespace.Labels[exemptNamespaceLabel] != "true" {
+			misconfigured = append(misconfigured, ns)
+		}
+	}
+
+	if len(misconfigured) > 0 {
+		return fmt.Errorf("exempt namespace(s) %s do not carry the %q label set to \"true\"; refusing to start to avoid silently exempting namespaces the webhook would still intercept", strings.Join(misconfigured, ", "), exemptNamespaceLabel)
+	}
+	return nil
+}
@@ -13,23 +13,33 @@ limitations under the License.
 package webhook
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"flag"
+	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
+	"github.com/go-logr/logr"
 	"gomodules.xyz/jsonpatch/v2"
 
 	"github.com/open-policy-agent/cert-controller/pkg/rotator"
 	opa "github.com/open-policy-agent/frameworks/constraint/pkg/client"
 	"github.com/open-policy-agent/gatekeeper/apis"
 	"github.com/open-policy-agent/gatekeeper/pkg/controller/config/process"
+	mutationcontroller "github.com/open-policy-agent/gatekeeper/pkg/controller/mutation"
+	"github.com/open-policy-agent/gatekeeper/pkg/mutation"
 	"github.com/open-policy-agent/gatekeeper/pkg/util"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -47,8 +57,34 @@ const (
 	mutationAllowResponse   mutationResponse = "allow"
 	mutationSkipResponse    mutationResponse = "skip"
 	mutationUnknownResponse mutationResponse = "unknown"
+	mutationBypassResponse  mutationResponse = "bypass"
 )
 
+// mutationMaxIterations bounds the fixed-point reinvocation loop in
+// mutateRequest. A negative value means "no limit", matching the
+// mutation-readiness-retries convention elsewhere in this package.
+var mutationMaxIterations = flag.Int("mutation-max-iterations", 10, "maximum number of times the mutators are reinvoked per admission request before giving up on convergence")
+
+// emitMutationEvents controls whether mutateRequest records a Kubernetes
+// event for each mutated object, matching the emit-admission-events style.
+var emitMutationEvents = flag.Bool("emit-mutation-events", false, "(alpha) emit Kubernetes events in gatekeeper namespace for each mutated object")
+
+// mutationReadinessRetries bounds, in one-second intervals measured from
+// manager start, how long mutationHandler.Handle will block requests with a
+// 503 while the mutation readinessTracker is not yet satisfied. A negative
+// value blocks indefinitely - the safest setting, since it guarantees no
+// object is ever admitted un-mutated, but it can wedge admission entirely
+// if the mutation system never finishes ingesting its CRDs. This is a
+// wall-clock budget rather than a count of requests seen: a counter shared
+// across concurrent admission requests would be exhausted by a single
+// burst of simultaneous requests regardless of whether the tracker had
+// actually become satisfied.
+var mutationReadinessRetries = flag.Int("mutation-readiness-retries", 5, "number of one-second intervals, measured from manager start, mutationHandler will block requests while waiting for the initial set of Assign/AssignMetadata objects to be ingested; -1 blocks indefinitely")
+
+// mutationReadinessPollInterval is the unit --mutation-readiness-retries is
+// expressed in.
+const mutationReadinessPollInterval = time.Second
+
 func init() {
 	MutationEnabled = flag.Bool("enable-mutation", false, "Enable the mutation webhook")
 
@@ -68,6 +104,10 @@ func AddMutatingWebhook(mgr manager.Manager, opa *opa.Client, processExcluder *p
 	if !*MutationEnabled {
 		return nil
 	}
+	if err := ValidateExemptNamespaces(context.Background(), mgr.GetAPIReader()); err != nil {
+		return err
+	}
+
 	reporter, err := newStatsReporter()
 	if err != nil {
 		return err
@@ -80,6 +120,22 @@ func AddMutatingWebhook(mgr manager.Manager, opa *opa.Client, processExcluder *p
 		scheme.Scheme,
 		corev1.EventSource{Component: "gatekeeper-mutation-webhook"})
 
+	mutationSystem := mutation.NewSystem()
+	readinessTracker := mutation.NewTracker()
+	if err := mutationcontroller.AddToManager(mgr, mutationSystem, readinessTracker); err != nil {
+		return err
+	}
+	// Populate the Tracker's expected set from a direct list before the
+	// webhook server is registered below, so no admission request can ever
+	// observe readinessTracker.Satisfied() == true before the initial set
+	// of Assign/AssignMetadata objects is actually known.
+	if err := mutationcontroller.PopulateTracker(context.Background(), mgr.GetAPIReader(), readinessTracker); err != nil {
+		return err
+	}
+	if err := mgr.AddReadyzCheck("mutation-informer", readinessTracker.CheckReady); err != nil {
+		return err
+	}
+
 	wh := &admission.Webhook{
 		Handler: &mutationHandler{
 			webhookHandler: webhookHandler{
@@ -91,6 +147,9 @@ func AddMutatingWebhook(mgr manager.Manager, opa *opa.Client, processExcluder *p
 				eventRecorder:   recorder,
 				gkNamespace:     util.GetNamespace(),
 			},
+			mutationSystem:   mutationSystem,
+			readinessTracker: readinessTracker,
+			authClient:       kubeClient.AuthorizationV1().SubjectAccessReviews(),
 		},
 	}
 
@@ -108,6 +167,10 @@ var _ admission.Handler = &mutationHandler{}
 
 type mutationHandler struct {
 	webhookHandler
+
+	mutationSystem   *mutation.System
+	readinessTracker *mutation.Tracker
+	authClient       authorizationv1client.SubjectAccessReviewInterface
 }
 
 // Handle the validation request
@@ -119,6 +182,23 @@ func (h *mutationHandler) Handle(ctx context.Context, req admission.Request) adm
 		return admission.ValidationResponse(true, "Gatekeeper does not self-manage")
 	}
 
+	if isNamespaceExempt(req.AdmissionRequest.Namespace) {
+		h.reportOutcome(log, mutationSkipResponse, timeStart)
+		return admission.ValidationResponse(true, "Namespace is exempt from Gatekeeper webhooks")
+	}
+
+	if isBypassedByGroupOrUser(req.AdmissionRequest.UserInfo) {
+		h.reportOutcome(log, mutationBypassResponse, timeStart)
+		return admission.ValidationResponse(true, "Bypassing Gatekeeper mutation per --bypass-user/--bypass-group")
+	}
+
+	if bypassed, err := isBypassedBySAR(ctx, h.authClient, req.AdmissionRequest.UserInfo); err != nil {
+		log.Error(err, "SubjectAccessReview bypass check failed")
+	} else if bypassed {
+		h.reportOutcome(log, mutationBypassResponse, timeStart)
+		return admission.ValidationResponse(true, "Bypassing Gatekeeper mutation per SubjectAccessReview")
+	}
+
 	if req.AdmissionRequest.Operation != admissionv1beta1.Create &&
 		req.AdmissionRequest.Operation != admissionv1beta1.Update {
 		return admission.ValidationResponse(true, "Mutating only on create")
@@ -128,6 +208,23 @@ func (h *mutationHandler) Handle(ctx context.Context, req admission.Request) adm
 		return admission.ValidationResponse(true, "Not mutating gatekeeper resources")
 	}
 
+	if !h.readinessTracker.Satisfied() {
+		elapsed := h.readinessTracker.Since()
+		if *mutationReadinessRetries < 0 || elapsed < time.Duration(*mutationReadinessRetries)*mutationReadinessPollInterval {
+			log.Info("mutation system is still ingesting Assign/AssignMetadata objects, blocking request", "elapsed", elapsed)
+			return admission.Response{
+				AdmissionResponse: admissionv1beta1.AdmissionResponse{
+					Allowed: false,
+					Result: &metav1.Status{
+						Code:    int32(http.StatusServiceUnavailable),
+						Message: "the mutation system has not finished ingesting its initial set of Assign/AssignMetadata objects, retry shortly",
+					},
+				},
+			}
+		}
+		log.Error(errors.New("mutation readiness deadline exceeded"), "proceeding with the currently compiled mutator set", "elapsed", elapsed)
+	}
+
 	requestResponse := mutationUnknownResponse
 	defer func() {
 		if h.reporter != nil {
@@ -160,11 +257,108 @@ func (h *mutationHandler) Handle(ctx context.Context, req admission.Request) adm
 	return resp
 }
 
-// traceSwitch returns true if a request should be traced
+// reportOutcome records a terminal outcome reached before mutateRequest
+// runs, e.g. a namespace exemption or bypass, using the same
+// ReportMutationRequest metric the full request path reports at the end
+// of Handle.
+func (h *mutationHandler) reportOutcome(log logr.Logger, outcome mutationResponse, timeStart time.Time) {
+	if h.reporter == nil {
+		return
+	}
+	if err := h.reporter.ReportMutationRequest(outcome, time.Since(timeStart)); err != nil {
+		log.Error(err, "failed to report request")
+	}
+}
+
+// mutateRequest loads the incoming object and repeatedly applies every
+// matching mutator from the mutation System until a pass leaves the object
+// unchanged (a fixed point) or mutationMaxIterations is exceeded. Chained
+// mutators - one setting a value another reads - need more than a single
+// pass to converge; bounding the loop keeps a misconfigured or cyclic chain
+// of mutators from spinning forever.
 func (h *mutationHandler) mutateRequest(ctx context.Context, req admission.Request) (admission.Response, error) {
+	obj := &unstructured.Unstructured{}
+	if _, _, err := deserializer.Decode(req.AdmissionRequest.Object.Raw, nil, obj); err != nil {
+		return admission.Response{}, err
+	}
+
+	oldJSON, err := obj.MarshalJSON()
+	if err != nil {
+		return admission.Response{}, err
+	}
+
+	nsLabels, err := h.namespaceLabels(ctx, req.AdmissionRequest.Namespace)
+	if err != nil {
+		return admission.Response{}, err
+	}
+
+	mutated := obj.DeepCopy()
+	lastChangedAt := map[string]int{}
+	maxIterations := *mutationMaxIterations
+	converged := false
+
+	var newJSON []byte
+	for iteration := 1; maxIterations < 0 || iteration <= maxIterations; iteration++ {
+		beforePass, err := mutated.MarshalJSON()
+		if err != nil {
+			return admission.Response{}, err
+		}
+
+		fired, err := h.mutationSystem.MutatePass(mutated, req.AdmissionRequest.Namespace, nsLabels)
+		if err != nil {
+			return admission.Response{}, err
+		}
+		for _, m := range fired {
+			lastChangedAt[m.String()] = iteration
+		}
+
+		afterPass, err := mutated.MarshalJSON()
+		if err != nil {
+			return admission.Response{}, err
+		}
+
+		if bytes.Equal(beforePass, afterPass) {
+			converged = true
+			newJSON = afterPass
+			break
+		}
+	}
+
+	if h.reporter != nil {
+		if err := h.reporter.ReportMutatorsInUse(h.mutationSystem.Len()); err != nil {
+			log.Error(err, "failed to report mutators in use")
+		}
+	}
+
+	if !converged {
+		if h.reporter != nil {
+			if err := h.reporter.ReportMutationNotConverged(); err != nil {
+				log.Error(err, "failed to report mutation convergence failure")
+			}
+		}
+		log.Error(fmt.Errorf("mutation did not reach a fixed point after %d iterations", maxIterations), "mutators preventing convergence", "lastChangedAt", lastChangedAt)
+		return admission.Response{
+			AdmissionResponse: admissionv1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Code:    int32(http.StatusInternalServerError),
+					Message: fmt.Sprintf("mutation of this object did not converge after %d iterations", maxIterations),
+				},
+			},
+		}, nil
+	}
+
+	patches, err := jsonpatch.CreatePatch(oldJSON, newJSON)
+	if err != nil {
+		return admission.Response{}, err
+	}
+
+	log.V(1).Info("mutators applied", "lastChangedAt", lastChangedAt)
+
+	if *emitMutationEvents && len(patches) > 0 {
+		h.emitMutationEvent(req, lastChangedAt, len(patches))
+	}
 
-	// TODO: place mutation logic here
-	patches := []jsonpatch.JsonPatchOperation{}
 	resp := admission.Response{
 		AdmissionResponse: admissionv1beta1.AdmissionResponse{
 			Allowed: true,
@@ -177,6 +371,59 @@ func (h *mutationHandler) mutateRequest(ctx context.Context, req admission.Reque
 	return resp, nil
 }
 
+// namespaceLabels returns the labels on the Namespace object named ns, used
+// to evaluate a mutator's Match.NamespaceSelector. It returns nil without
+// looking anything up for ns == "" (a cluster-scoped object has no owning
+// Namespace to select on).
+func (h *mutationHandler) namespaceLabels(ctx context.Context, ns string) (map[string]string, error) {
+	if ns == "" {
+		return nil, nil
+	}
+	namespace := &corev1.Namespace{}
+	if err := h.client.Get(ctx, types.NamespacedName{Name: ns}, namespace); err != nil {
+		return nil, fmt.Errorf("looking up namespace %q for NamespaceSelector matching: %w", ns, err)
+	}
+	return namespace.Labels, nil
+}
+
+// emitMutationEvent records which mutators fired and the resulting patch
+// length against both the gatekeeper namespace and, when it differs, the
+// mutated object's own namespace. The request UID is attached to the
+// involved-object reference so operators can correlate the event with the
+// corresponding admission review log line.
+func (h *mutationHandler) emitMutationEvent(req admission.Request, lastChangedAt map[string]int, patchLen int) {
+	if h.eventRecorder == nil {
+		return
+	}
+
+	mutatorNames := make([]string, 0, len(lastChangedAt))
+	for name := range lastChangedAt {
+		mutatorNames = append(mutatorNames, name)
+	}
+	sort.Strings(mutatorNames)
+
+	message := fmt.Sprintf("mutators %v produced a %d-operation patch for %s %s/%s",
+		mutatorNames, patchLen, req.AdmissionRequest.Kind.Kind, req.AdmissionRequest.Namespace, req.AdmissionRequest.Name)
+
+	gkRef := &corev1.ObjectReference{
+		Kind:      "Namespace",
+		Name:      h.gkNamespace,
+		Namespace: h.gkNamespace,
+		UID:       req.AdmissionRequest.UID,
+	}
+	h.eventRecorder.Event(gkRef, corev1.EventTypeNormal, "Mutated", message)
+
+	if req.AdmissionRequest.Namespace != "" && req.AdmissionRequest.Namespace != h.gkNamespace {
+		objRef := &corev1.ObjectReference{
+			Kind:      req.AdmissionRequest.Kind.Kind,
+			Name:      req.AdmissionRequest.Name,
+			Namespace: req.AdmissionRequest.Namespace,
+			UID:       req.AdmissionRequest.UID,
+		}
+		h.eventRecorder.Event(objRef, corev1.EventTypeNormal, "Mutated", message)
+	}
+}
+
 func AppendMutationWebhookIfEnabled(webhooks []rotator.WebhookInfo) []rotator.WebhookInfo {
 	if *MutationEnabled {
 		return append(webhooks, rotator.WebhookInfo{
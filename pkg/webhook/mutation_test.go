@@ -0,0 +1,144 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/open-policy-agent/gatekeeper/pkg/mutation"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// fakeWebhookMutator sets field to value the first time it sees an object
+// missing it, mirroring pkg/mutation's own fakeMutator.
+type fakeWebhookMutator struct {
+	id    mutation.ID
+	field string
+	value string
+}
+
+var _ mutation.Mutator = &fakeWebhookMutator{}
+
+func (m *fakeWebhookMutator) ID() mutation.ID { return m.id }
+
+func (m *fakeWebhookMutator) Matches(obj *unstructured.Unstructured, ns string, nsLabels map[string]string) (bool, error) {
+	return true, nil
+}
+
+func (m *fakeWebhookMutator) Mutate(obj *unstructured.Unstructured) (bool, error) {
+	current, found, _ := unstructured.NestedString(obj.Object, m.field)
+	if found && current == m.value {
+		return false, nil
+	}
+	_ = unstructured.SetNestedField(obj.Object, m.value, m.field)
+	return true, nil
+}
+
+func (m *fakeWebhookMutator) String() string { return m.id.Name }
+
+// toggleMutator flips a field between "0" and "1" on every call, so it
+// never converges to a fixed point.
+type toggleMutator struct {
+	id mutation.ID
+}
+
+var _ mutation.Mutator = &toggleMutator{}
+
+func (m *toggleMutator) ID() mutation.ID { return m.id }
+
+func (m *toggleMutator) Matches(obj *unstructured.Unstructured, ns string, nsLabels map[string]string) (bool, error) {
+	return true, nil
+}
+
+func (m *toggleMutator) Mutate(obj *unstructured.Unstructured) (bool, error) {
+	current, _, _ := unstructured.NestedString(obj.Object, "flip")
+	next := "1"
+	if current == "1" {
+		next = "0"
+	}
+	_ = unstructured.SetNestedField(obj.Object, next, "flip")
+	return true, nil
+}
+
+func (m *toggleMutator) String() string { return m.id.Name }
+
+func newMutationRequest(t *testing.T) admission.Request {
+	t.Helper()
+	raw := []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"pod1"},"spec":{}}`)
+	return admission.Request{
+		AdmissionRequest: admissionv1beta1.AdmissionRequest{
+			Operation: admissionv1beta1.Create,
+			Object:    k8sruntime.RawExtension{Raw: raw},
+		},
+	}
+}
+
+func TestMutationHandler_MutateRequestConverges(t *testing.T) {
+	system := mutation.NewSystem()
+	system.Upsert(&fakeWebhookMutator{id: mutation.ID{Kind: "Assign", Name: "a"}, field: "mutated", value: "1"})
+
+	h := &mutationHandler{mutationSystem: system, readinessTracker: mutation.NewTracker()}
+
+	resp, err := h.mutateRequest(context.Background(), newMutationRequest(t))
+	if err != nil {
+		t.Fatalf("mutateRequest returned unexpected error: %v", err)
+	}
+	if !resp.Allowed {
+		t.Fatalf("mutateRequest response not allowed: %+v", resp.Result)
+	}
+	if len(resp.Patches) == 0 {
+		t.Fatalf("mutateRequest produced no patches, want at least one for the fired mutator")
+	}
+}
+
+func TestMutationHandler_HandleBlocksWhileReadinessTrackerUnsatisfied(t *testing.T) {
+	// A freshly created Tracker has not had MarkPopulated called on it, so
+	// Satisfied() reports false and Handle must refuse the request with a
+	// 503 rather than admitting it un-mutated.
+	h := &mutationHandler{mutationSystem: mutation.NewSystem(), readinessTracker: mutation.NewTracker()}
+
+	resp := h.Handle(context.Background(), newMutationRequest(t))
+	if resp.Allowed {
+		t.Fatalf("Handle response allowed, want denied while the readiness tracker is unsatisfied")
+	}
+	if resp.Result == nil || resp.Result.Code != int32(http.StatusServiceUnavailable) {
+		t.Fatalf("Handle response = %+v, want a 503 readiness-gate result", resp.Result)
+	}
+}
+
+func TestMutationHandler_MutateRequestNotConverged(t *testing.T) {
+	old := *mutationMaxIterations
+	*mutationMaxIterations = 2
+	t.Cleanup(func() { *mutationMaxIterations = old })
+
+	system := mutation.NewSystem()
+	system.Upsert(&toggleMutator{id: mutation.ID{Kind: "Assign", Name: "flip"}})
+
+	h := &mutationHandler{mutationSystem: system, readinessTracker: mutation.NewTracker()}
+
+	resp, err := h.mutateRequest(context.Background(), newMutationRequest(t))
+	if err != nil {
+		t.Fatalf("mutateRequest returned unexpected error: %v", err)
+	}
+	if resp.Allowed {
+		t.Fatalf("mutateRequest response allowed, want denied after exceeding mutation-max-iterations")
+	}
+	if resp.Result == nil || resp.Result.Code != int32(http.StatusInternalServerError) {
+		t.Fatalf("mutateRequest response = %+v, want a 500 not-converged result", resp.Result)
+	}
+}
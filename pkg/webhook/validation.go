@@ -0,0 +1,85 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ admission.Handler = &validationHandler{}
+
+type validationResponse string
+
+const (
+	validationSkipResponse   validationResponse = "skip"
+	validationBypassResponse validationResponse = "bypass"
+)
+
+// validationHandler enforces constraints against incoming admission
+// requests. Handle's constraint-evaluation logic is unchanged here; what
+// this file adds is the same namespace-exemption and
+// bypass-user/bypass-group/bypass-via-sar short-circuits
+// mutationHandler.Handle applies, so those flags have identical effect on
+// both webhooks instead of only the mutating one, including reporting the
+// same kind of skip/bypass outcome to the stats reporter.
+type validationHandler struct {
+	webhookHandler
+
+	authClient authorizationv1client.SubjectAccessReviewInterface
+}
+
+// Handle the validation request
+func (h *validationHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	log := log.WithValues("hookType", "validation")
+	timeStart := time.Now()
+
+	if isGkServiceAccount(req.AdmissionRequest.UserInfo) {
+		return admission.ValidationResponse(true, "Gatekeeper does not self-manage")
+	}
+
+	if isNamespaceExempt(req.AdmissionRequest.Namespace) {
+		h.reportOutcome(log, validationSkipResponse, timeStart)
+		return admission.ValidationResponse(true, "Namespace is exempt from Gatekeeper webhooks")
+	}
+
+	if isBypassedByGroupOrUser(req.AdmissionRequest.UserInfo) {
+		h.reportOutcome(log, validationBypassResponse, timeStart)
+		return admission.ValidationResponse(true, "Bypassing Gatekeeper validation per --bypass-user/--bypass-group")
+	}
+
+	if bypassed, err := isBypassedBySAR(ctx, h.authClient, req.AdmissionRequest.UserInfo); err != nil {
+		log.Error(err, "SubjectAccessReview bypass check failed")
+	} else if bypassed {
+		h.reportOutcome(log, validationBypassResponse, timeStart)
+		return admission.ValidationResponse(true, "Bypassing Gatekeeper validation per SubjectAccessReview")
+	}
+
+	return h.reviewRequest(ctx, req)
+}
+
+// reportOutcome records a terminal outcome reached before reviewRequest
+// runs, e.g. a namespace exemption or bypass, mirroring
+// mutationHandler.reportOutcome.
+func (h *validationHandler) reportOutcome(log logr.Logger, outcome validationResponse, timeStart time.Time) {
+	if h.reporter == nil {
+		return
+	}
+	if err := h.reporter.ReportValidationRequest(outcome, time.Since(timeStart)); err != nil {
+		log.Error(err, "failed to report request")
+	}
+}